@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/budget"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/gateway"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider"
+)
+
+// newBudgetStore picks a Redis-backed budget.Store when REDIS_ADDR is set,
+// so multiple gateway instances share one set of usage counters; otherwise
+// it falls back to an in-process MemoryStore for single-instance deployments.
+func newBudgetStore() budget.Store {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		return budget.NewMemoryStore()
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+	return budget.NewRedisStore(client, "budget:")
+}
+
+func main() {
+	log.SetFormatter(&log.TextFormatter{FullTimestamp: true})
+
+	configPath := os.Getenv("PROVIDER_CONFIG")
+	if configPath == "" {
+		configPath = "config/providers.yaml"
+	}
+
+	cfg, err := provider.LoadConfig(configPath)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load provider config")
+	}
+
+	adapters, err := provider.BuildAdapters(cfg.Providers)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to build provider adapters")
+	}
+
+	registry := provider.NewRegistry(cfg.Providers)
+	health := provider.NewHealthTracker(2*time.Second, 2*time.Minute)
+	client := provider.NewClient(registry, health, adapters)
+
+	budgetConfigPath := os.Getenv("BUDGET_CONFIG")
+	if budgetConfigPath == "" {
+		budgetConfigPath = "config/budget.yaml"
+	}
+	budgetCfg, err := budget.LoadConfig(budgetConfigPath)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load budget config")
+	}
+	budgetLimiter := budget.NewLimiter(newBudgetStore(), budgetCfg)
+
+	handler := gateway.NewHandler(client, registry, health, budgetLimiter, configPath)
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(gateway.RequestIDMiddleware())
+	r.Use(gateway.LoggingMiddleware())
+
+	r.POST("/v1/chat/completions", gateway.BudgetMiddleware(budgetLimiter), handler.ChatCompletion)
+	r.GET("/health", handler.Health)
+	r.GET("/providers", handler.ListProviders)
+	r.GET("/providers/:id/health", handler.ProviderHealth)
+	r.POST("/providers/reload", handler.ReloadProviders)
+	r.GET("/usage/:api_key", handler.Usage)
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8000"
+	}
+
+	log.Infof("Gateway starting on :%s", port)
+	if err := r.Run(":" + port); err != nil {
+		log.WithError(err).Fatal("Gateway exited")
+	}
+}
@@ -0,0 +1,99 @@
+package budget
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyLimits describes the token caps that apply to a single API key.
+type KeyLimits struct {
+	MonthlyCap int `yaml:"monthly_cap"` // 0 means unlimited
+	RequestCap int `yaml:"request_cap"` // 0 means unlimited
+}
+
+// Config is the top-level budget configuration document: a default limit
+// applied to every key, plus overrides for specific ones.
+type Config struct {
+	Default KeyLimits            `yaml:"default"`
+	Keys    map[string]KeyLimits `yaml:"keys"`
+}
+
+// LoadConfig reads budget limits from a YAML file at path and layers
+// environment variable overrides on top.
+//
+// For an API key "acme-prod", the following env vars are recognized:
+//
+//	BUDGET_ACME_PROD_MONTHLY_CAP, BUDGET_ACME_PROD_REQUEST_CAP
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read budget config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse budget config: %w", err)
+	}
+	if cfg.Keys == nil {
+		cfg.Keys = make(map[string]KeyLimits)
+	}
+
+	applyDefaultEnvOverrides(&cfg.Default)
+	for key, limits := range cfg.Keys {
+		applyKeyEnvOverrides(key, &limits)
+		cfg.Keys[key] = limits
+	}
+
+	return &cfg, nil
+}
+
+// LimitsFor returns the limits that apply to key, falling back to the
+// configured default when the key has no specific entry.
+func (c *Config) LimitsFor(key string) KeyLimits {
+	if limits, ok := c.Keys[key]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+func applyDefaultEnvOverrides(limits *KeyLimits) {
+	if v := os.Getenv("BUDGET_DEFAULT_MONTHLY_CAP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limits.MonthlyCap = n
+		}
+	}
+	if v := os.Getenv("BUDGET_DEFAULT_REQUEST_CAP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limits.RequestCap = n
+		}
+	}
+}
+
+func applyKeyEnvOverrides(key string, limits *KeyLimits) {
+	prefix := "BUDGET_" + strings.ToUpper(sanitizeEnvKey(key)) + "_"
+
+	if v := os.Getenv(prefix + "MONTHLY_CAP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limits.MonthlyCap = n
+		}
+	}
+	if v := os.Getenv(prefix + "REQUEST_CAP"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limits.RequestCap = n
+		}
+	}
+}
+
+// sanitizeEnvKey makes an API key safe to splice into an env var name.
+func sanitizeEnvKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,98 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a request would push an API key past
+// its monthly or per-request token cap.
+type ErrBudgetExceeded struct {
+	APIKey string
+	Reason string // "monthly_cap" or "request_cap"
+	Cap    int
+}
+
+func (e *ErrBudgetExceeded) Error() string {
+	return fmt.Sprintf("api key %s exceeded %s (cap %d)", e.APIKey, e.Reason, e.Cap)
+}
+
+// Usage is a point-in-time snapshot of an API key's token spend for the
+// current calendar month, returned by the admin usage endpoint.
+type Usage struct {
+	APIKey     string `json:"api_key"`
+	Month      string `json:"month"`
+	TokensUsed int    `json:"tokens_used"`
+	MonthlyCap int    `json:"monthly_cap,omitempty"`
+	RequestCap int    `json:"request_cap,omitempty"`
+}
+
+// Limiter enforces per-API-key token budgets, checking an estimated cost
+// before a request is sent upstream and reconciling it against the actual
+// cost once the call completes.
+type Limiter struct {
+	store Store
+	cfg   *Config
+}
+
+// NewLimiter builds a Limiter backed by store, using cfg for per-key caps.
+func NewLimiter(store Store, cfg *Config) *Limiter {
+	return &Limiter{store: store, cfg: cfg}
+}
+
+// CheckAndReserve rejects the request with ErrBudgetExceeded if estimatedTokens
+// would push apiKey past its per-request cap or its monthly cap has already
+// been reached. It does not itself record estimatedTokens against the
+// counter — RecordUsage does that once the actual cost is known, so a
+// request that fails upstream doesn't burn budget it never spent.
+func (l *Limiter) CheckAndReserve(ctx context.Context, apiKey string, estimatedTokens int) error {
+	limits := l.cfg.LimitsFor(apiKey)
+
+	if limits.RequestCap > 0 && estimatedTokens > limits.RequestCap {
+		return &ErrBudgetExceeded{APIKey: apiKey, Reason: "request_cap", Cap: limits.RequestCap}
+	}
+
+	if limits.MonthlyCap > 0 {
+		spent, err := l.store.Get(ctx, apiKey, monthKey(time.Now()))
+		if err != nil {
+			return fmt.Errorf("check monthly budget: %w", err)
+		}
+		if spent+estimatedTokens > limits.MonthlyCap {
+			return &ErrBudgetExceeded{APIKey: apiKey, Reason: "monthly_cap", Cap: limits.MonthlyCap}
+		}
+	}
+
+	return nil
+}
+
+// RecordUsage adds tokens to apiKey's monthly counter after a request
+// completes successfully.
+func (l *Limiter) RecordUsage(ctx context.Context, apiKey string, tokens int) error {
+	if tokens <= 0 {
+		return nil
+	}
+	_, err := l.store.Add(ctx, apiKey, monthKey(time.Now()), tokens)
+	if err != nil {
+		return fmt.Errorf("record budget usage: %w", err)
+	}
+	return nil
+}
+
+// Status returns apiKey's current month-to-date usage and configured caps.
+func (l *Limiter) Status(ctx context.Context, apiKey string) (Usage, error) {
+	month := monthKey(time.Now())
+	spent, err := l.store.Get(ctx, apiKey, month)
+	if err != nil {
+		return Usage{}, fmt.Errorf("get budget usage: %w", err)
+	}
+
+	limits := l.cfg.LimitsFor(apiKey)
+	return Usage{
+		APIKey:     apiKey,
+		Month:      month,
+		TokensUsed: spent,
+		MonthlyCap: limits.MonthlyCap,
+		RequestCap: limits.RequestCap,
+	}, nil
+}
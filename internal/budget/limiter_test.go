@@ -0,0 +1,103 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLimiterCheckAndReserveRejectsOverRequestCap(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{Default: KeyLimits{RequestCap: 100}, Keys: map[string]KeyLimits{}})
+
+	err := l.CheckAndReserve(context.Background(), "acme", 150)
+	var budgetErr *ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) || budgetErr.Reason != "request_cap" {
+		t.Fatalf("expected a request_cap ErrBudgetExceeded, got %v", err)
+	}
+}
+
+func TestLimiterCheckAndReserveRejectsOverMonthlyCap(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{Default: KeyLimits{MonthlyCap: 100}, Keys: map[string]KeyLimits{}})
+	ctx := context.Background()
+
+	if err := l.RecordUsage(ctx, "acme", 90); err != nil {
+		t.Fatalf("record usage: %v", err)
+	}
+
+	err := l.CheckAndReserve(ctx, "acme", 20)
+	var budgetErr *ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) || budgetErr.Reason != "monthly_cap" {
+		t.Fatalf("expected a monthly_cap ErrBudgetExceeded, got %v", err)
+	}
+}
+
+func TestLimiterCheckAndReserveAllowsWithinCaps(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{Default: KeyLimits{MonthlyCap: 100, RequestCap: 50}, Keys: map[string]KeyLimits{}})
+
+	if err := l.CheckAndReserve(context.Background(), "acme", 40); err != nil {
+		t.Fatalf("expected request within caps to be allowed, got %v", err)
+	}
+}
+
+func TestLimiterCheckAndReserveUnlimitedWhenCapsAreZero(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{Default: KeyLimits{}, Keys: map[string]KeyLimits{}})
+
+	if err := l.CheckAndReserve(context.Background(), "acme", 1_000_000); err != nil {
+		t.Fatalf("expected a zero cap to mean unlimited, got %v", err)
+	}
+}
+
+func TestLimiterRecordUsageDoesNotCountFailedOrZeroUsage(t *testing.T) {
+	store := NewMemoryStore()
+	l := NewLimiter(store, &Config{Default: KeyLimits{MonthlyCap: 100}, Keys: map[string]KeyLimits{}})
+	ctx := context.Background()
+
+	if err := l.RecordUsage(ctx, "acme", 0); err != nil {
+		t.Fatalf("record usage: %v", err)
+	}
+
+	status, err := l.Status(ctx, "acme")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.TokensUsed != 0 {
+		t.Fatalf("expected recording 0 tokens to be a no-op, got %d used", status.TokensUsed)
+	}
+}
+
+func TestLimiterUsesPerKeyOverrideInsteadOfDefault(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{
+		Default: KeyLimits{RequestCap: 10},
+		Keys:    map[string]KeyLimits{"vip": {RequestCap: 1000}},
+	})
+
+	if err := l.CheckAndReserve(context.Background(), "vip", 500); err != nil {
+		t.Fatalf("expected vip's override cap to apply, got %v", err)
+	}
+
+	err := l.CheckAndReserve(context.Background(), "regular", 500)
+	var budgetErr *ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected the default cap to apply to keys with no override, got %v", err)
+	}
+}
+
+func TestLimiterStatusReportsMonthToDateUsageAndCaps(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), &Config{Default: KeyLimits{MonthlyCap: 100, RequestCap: 50}, Keys: map[string]KeyLimits{}})
+	ctx := context.Background()
+
+	if err := l.RecordUsage(ctx, "acme", 30); err != nil {
+		t.Fatalf("record usage: %v", err)
+	}
+
+	status, err := l.Status(ctx, "acme")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.TokensUsed != 30 {
+		t.Fatalf("expected 30 tokens used, got %d", status.TokensUsed)
+	}
+	if status.MonthlyCap != 100 || status.RequestCap != 50 {
+		t.Fatalf("expected configured caps to be reported, got %+v", status)
+	}
+}
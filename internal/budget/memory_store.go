@@ -0,0 +1,36 @@
+package budget
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is an in-process Store, used by default for single-instance
+// deployments or local development.
+type MemoryStore struct {
+	mu     sync.Mutex
+	totals map[string]int // "<apiKey>:<month>" -> tokens
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{totals: make(map[string]int)}
+}
+
+// Add adds tokens to apiKey's counter for month and returns the new total.
+func (s *MemoryStore) Add(ctx context.Context, apiKey, month string, tokens int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := apiKey + ":" + month
+	s.totals[key] += tokens
+	return s.totals[key], nil
+}
+
+// Get returns apiKey's current counter for month, 0 if unset.
+func (s *MemoryStore) Get(ctx context.Context, apiKey, month string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.totals[apiKey+":"+month], nil
+}
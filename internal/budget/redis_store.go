@@ -0,0 +1,47 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store backed by Redis, for deployments running more than
+// one gateway instance that need to share usage counters.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore wraps an existing Redis client. Keys are namespaced under
+// prefix (e.g. "budget:") so counters don't collide with other uses of the
+// same Redis instance.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) key(apiKey, month string) string {
+	return s.prefix + apiKey + ":" + month
+}
+
+// Add adds tokens to apiKey's counter for month and returns the new total.
+func (s *RedisStore) Add(ctx context.Context, apiKey, month string, tokens int) (int, error) {
+	total, err := s.client.IncrBy(ctx, s.key(apiKey, month), int64(tokens)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("incr budget counter: %w", err)
+	}
+	return int(total), nil
+}
+
+// Get returns apiKey's current counter for month, 0 if unset.
+func (s *RedisStore) Get(ctx context.Context, apiKey, month string) (int, error) {
+	val, err := s.client.Get(ctx, s.key(apiKey, month)).Int()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get budget counter: %w", err)
+	}
+	return val, nil
+}
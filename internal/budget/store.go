@@ -0,0 +1,23 @@
+package budget
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists per-key, per-month token counters. MemoryStore is used by
+// default; RedisStore is a drop-in replacement for multi-instance
+// deployments where counters need to be shared across processes.
+type Store interface {
+	// Add adds tokens to apiKey's counter for month and returns the new
+	// total.
+	Add(ctx context.Context, apiKey, month string, tokens int) (int, error)
+	// Get returns apiKey's current counter for month, 0 if unset.
+	Get(ctx context.Context, apiKey, month string) (int, error)
+}
+
+// monthKey returns the calendar-month bucket a usage counter belongs in,
+// e.g. "2026-07".
+func monthKey(t time.Time) string {
+	return t.Format("2006-01")
+}
@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider"
+)
+
+// ListProviders handles GET /providers, returning the configured providers
+// in priority order along with their current health.
+func (h *Handler) ListProviders(c *gin.Context) {
+	ordered := h.registry.Ordered()
+
+	out := make([]gin.H, 0, len(ordered))
+	for _, p := range ordered {
+		out = append(out, gin.H{
+			"id":         p.ID,
+			"name":       p.Name,
+			"adapter":    p.Adapter,
+			"priority":   p.Priority,
+			"weight":     p.Weight,
+			"health":     h.health.Status(p.ID),
+			"breaker":    h.providerClient.Breaker.Status(p.ID),
+			"rate_limit": h.providerClient.RateLimiter.Status(p.ID),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"providers": out})
+}
+
+// ProviderHealth handles GET /providers/:id/health.
+func (h *Handler) ProviderHealth(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, ok := h.registry.Get(id); !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"type":    "not_found",
+				"message": (&provider.ErrUnknownProvider{ID: id}).Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"health":     h.health.Status(id),
+		"breaker":    h.providerClient.Breaker.Status(id),
+		"rate_limit": h.providerClient.RateLimiter.Status(id),
+	})
+}
+
+// ReloadProviders handles POST /providers/reload: it re-reads the provider
+// config file and replaces the registry's providers, and clears any
+// permanent (401/403) lockouts recorded against the old configuration. This
+// is the only way to recover a provider the health tracker has permanently
+// marked down, since that lockout is deliberately not time-based.
+func (h *Handler) ReloadProviders(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	cfg, err := provider.LoadConfig(h.configPath)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"request_id": requestID,
+			"error":      err.Error(),
+			"event":      "provider_reload_failed",
+		}).Error("Failed to reload provider config")
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"type":    "internal_error",
+				"message": "Failed to reload provider config: " + err.Error(),
+			},
+		})
+		return
+	}
+
+	h.registry.Reload(cfg.Providers)
+	h.health.Reload()
+
+	log.WithFields(log.Fields{
+		"request_id": requestID,
+		"providers":  len(cfg.Providers),
+		"event":      "provider_reload",
+	}).Info("Reloaded provider config")
+
+	c.JSON(http.StatusOK, gin.H{"reloaded": true, "providers": len(cfg.Providers)})
+}
+
+// Usage handles GET /usage/:api_key, reporting the key's current
+// month-to-date token spend and configured caps.
+func (h *Handler) Usage(c *gin.Context) {
+	if h.budgetLimiter == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"error": gin.H{
+				"type":    "budget_disabled",
+				"message": "budget tracking is not enabled on this gateway",
+			},
+		})
+		return
+	}
+
+	apiKey := c.Param("api_key")
+	usage, err := h.budgetLimiter.Status(c.Request.Context(), apiKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"type":    "internal_error",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/budget"
 	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
 	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider"
 	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/validator"
@@ -16,11 +17,17 @@ import (
 // Handler handles HTTP requests for the gateway
 type Handler struct {
 	providerClient *provider.Client
+	registry       *provider.Registry
+	health         *provider.HealthTracker
+	budgetLimiter  *budget.Limiter
+	configPath     string
 }
 
-// NewHandler creates a new Handler
-func NewHandler(client *provider.Client) *Handler {
-	return &Handler{providerClient: client}
+// NewHandler creates a new Handler. configPath is the provider config file
+// re-read by ReloadProviders, e.g. after an operator rotates a
+// misconfigured API key that tripped a provider's permanent lockout.
+func NewHandler(client *provider.Client, registry *provider.Registry, health *provider.HealthTracker, budgetLimiter *budget.Limiter, configPath string) *Handler {
+	return &Handler{providerClient: client, registry: registry, health: health, budgetLimiter: budgetLimiter, configPath: configPath}
 }
 
 // ChatCompletion handles POST /v1/chat/completions
@@ -120,21 +127,47 @@ func (h *Handler) handleNonStreamingRequest(c *gin.Context, ctx context.Context,
 		Model:          req.Model,
 		Provider:       response.Provider,
 		Attempts:       response.Attempts,
+		AttemptLog:     response.AttemptLog,
 		TotalLatencyMs: time.Since(start).Milliseconds(),
+		TokensUsed:     response.TokensUsed,
 		CreatedAt:      time.Now(),
 	}
 
+	h.recordUsage(ctx, c, requestID, response.TokensUsed)
+
 	log.WithFields(log.Fields{
-		"request_id": requestID,
-		"provider":   response.Provider,
-		"attempts":   response.Attempts,
-		"latency_ms": result.TotalLatencyMs,
-		"event":      "success",
+		"request_id":  requestID,
+		"provider":    response.Provider,
+		"attempts":    response.Attempts,
+		"latency_ms":  result.TotalLatencyMs,
+		"tokens_used": response.TokensUsed,
+		"event":       "success",
 	}).Info("Request successful")
 
 	c.JSON(http.StatusOK, result)
 }
 
+// recordUsage charges tokensUsed against the caller's budget, if budget
+// enforcement is enabled, and stamps it on the gin context for logging
+// middleware further up the chain.
+func (h *Handler) recordUsage(ctx context.Context, c *gin.Context, requestID string, tokensUsed int) {
+	c.Set("tokens_used", tokensUsed)
+
+	if h.budgetLimiter == nil {
+		return
+	}
+
+	apiKey := c.GetString("api_key")
+	if err := h.budgetLimiter.RecordUsage(ctx, apiKey, tokensUsed); err != nil {
+		log.WithFields(log.Fields{
+			"request_id": requestID,
+			"api_key":    apiKey,
+			"error":      err.Error(),
+			"event":      "budget_record_failed",
+		}).Warn("Failed to record token usage against budget")
+	}
+}
+
 func (h *Handler) handleStreamingRequest(c *gin.Context, ctx context.Context, req *models.Request, requestID string, start time.Time) {
 	// Set SSE headers
 	c.Header("Content-Type", "text/event-stream")
@@ -143,7 +176,7 @@ func (h *Handler) handleStreamingRequest(c *gin.Context, ctx context.Context, re
 	c.Header("X-Request-ID", requestID)
 
 	// Call provider with streaming
-	err := h.providerClient.CallStream(ctx, *req, c.Writer)
+	result, err := h.providerClient.CallStream(ctx, *req, c.Writer)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"request_id": requestID,
@@ -155,10 +188,13 @@ func (h *Handler) handleStreamingRequest(c *gin.Context, ctx context.Context, re
 		return
 	}
 
+	h.recordUsage(ctx, c, requestID, result.TokensUsed)
+
 	log.WithFields(log.Fields{
-		"request_id": requestID,
-		"latency_ms": time.Since(start).Milliseconds(),
-		"event":      "stream_complete",
+		"request_id":  requestID,
+		"latency_ms":  time.Since(start).Milliseconds(),
+		"tokens_used": result.TokensUsed,
+		"event":       "stream_complete",
 	}).Info("Streaming complete")
 }
 
@@ -169,4 +205,3 @@ func (h *Handler) Health(c *gin.Context) {
 		"time":   time.Now().UTC().Format(time.RFC3339),
 	})
 }
-
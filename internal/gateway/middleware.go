@@ -0,0 +1,142 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/budget"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/usage"
+)
+
+// defaultCompletionTokenEstimate is the conservative completion-length
+// assumption used to pre-flight a request's cost when the caller didn't set
+// max_tokens, mirroring the same fallback the anthropic adapter uses when
+// translating a request upstream.
+const defaultCompletionTokenEstimate = 1024
+
+// RequestIDMiddleware generates a unique ID for each request
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Generate unique ID: "req_a1b2c3d4"
+		requestID := "req_" + uuid.New().String()[:8]
+
+		// Store in Gin context (accessible throughout request lifecycle)
+		c.Set("request_id", requestID)
+
+		// Return in response header for client debugging
+		c.Header("X-Request-ID", requestID)
+
+		// Continue to next middleware/handler
+		c.Next()
+	}
+}
+
+// LoggingMiddleware logs request start/end with timing
+func LoggingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := c.GetString("request_id")
+
+		log.WithFields(log.Fields{
+			"request_id": requestID,
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"event":      "started",
+		}).Info("Request started")
+
+		// Process request
+		c.Next()
+
+		// Log completion
+		log.WithFields(log.Fields{
+			"request_id": requestID,
+			"status":     c.Writer.Status(),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"event":      "completed",
+		}).Info("Request completed")
+	}
+}
+
+// apiKeyFromRequest extracts the caller's API key from the Authorization
+// header ("Bearer <key>"), falling back to "anonymous" for unauthenticated
+// callers so budget enforcement still applies a default cap to them.
+func apiKeyFromRequest(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if key := strings.TrimPrefix(auth, "Bearer "); key != auth && key != "" {
+		return key
+	}
+	return "anonymous"
+}
+
+// BudgetMiddleware enforces per-API-key token budgets before a request
+// reaches the provider client: it peeks at the request body to estimate its
+// cost, rejecting with 402 if that would exceed the key's per-request or
+// remaining monthly cap. The handler records the call's actual token cost
+// against the budget once it completes, via limiter.RecordUsage.
+func BudgetMiddleware(limiter *budget.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetString("request_id")
+		apiKey := apiKeyFromRequest(c.Request)
+		c.Set("api_key", apiKey)
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Request.Body = io.NopCloser(bytes.NewReader(nil))
+		} else {
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		var peek struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+			MaxTokens int `json:"max_tokens"`
+		}
+		json.Unmarshal(body, &peek)
+
+		var prompt strings.Builder
+		for _, m := range peek.Messages {
+			prompt.WriteString(m.Content)
+			prompt.WriteByte('\n')
+		}
+		promptTokens := usage.EstimateTokens(peek.Model, prompt.String())
+
+		// A caller that didn't set max_tokens isn't free to generate: fall
+		// back to a conservative completion estimate instead of treating
+		// "unset" as "zero cost", which would make the per-request cap inert
+		// for exactly the requests most likely to blow past it.
+		completionTokens := peek.MaxTokens
+		if completionTokens <= 0 {
+			completionTokens = defaultCompletionTokenEstimate
+		}
+		estimatedTokens := promptTokens + completionTokens
+
+		if err := limiter.CheckAndReserve(c.Request.Context(), apiKey, estimatedTokens); err != nil {
+			log.WithFields(log.Fields{
+				"request_id": requestID,
+				"api_key":    apiKey,
+				"error":      err.Error(),
+				"event":      "budget_exceeded",
+			}).Warn("Rejecting request over budget")
+
+			c.AbortWithStatusJSON(http.StatusPaymentRequired, gin.H{
+				"error": gin.H{
+					"type":    "budget_exceeded",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -25,11 +25,20 @@ type Response struct {
     Model           string    `json:"model"`
     Provider        string    `json:"provider"`
     Attempts        int       `json:"attempts"`
+    AttemptLog      []Attempt `json:"attempt_log,omitempty"`
     TotalLatencyMs  int64     `json:"total_latency_ms"`
     TokensUsed      int       `json:"tokens_used,omitempty"`
     CreatedAt       time.Time `json:"created_at"`
 }
 
+// ProviderResponse is the normalized shape a provider.Adapter parses a raw
+// upstream response into, before it is folded into a Response.
+type ProviderResponse struct {
+    Content        string `json:"content"`
+    FinishReason   string `json:"finish_reason,omitempty"`
+    TokensUsed     int    `json:"tokens_used,omitempty"`
+}
+
 // Attempt represents a single attempt to fulfill a request
 type Attempt struct {
     RequestID      string    `json:"request_id"`
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+// Adapter translates between the gateway's canonical request/response shape
+// and a specific upstream provider's wire format, so the router and client
+// never need to know which provider they are talking to.
+type Adapter interface {
+	// Translate builds the upstream HTTP request for req.
+	Translate(req models.Request) (*http.Request, error)
+
+	// ParseResponse reads a completed (non-streaming) upstream response into
+	// the canonical ProviderResponse shape.
+	ParseResponse(resp *http.Response) (models.ProviderResponse, error)
+
+	// ParseStream reads the upstream SSE stream from r and writes
+	// OpenAI-compatible SSE chunks to w, so downstream consumers see one
+	// canonical stream schema regardless of provider.
+	ParseStream(r io.Reader, w io.Writer) error
+}
@@ -0,0 +1,169 @@
+// Package anthropic adapts the gateway's canonical request/response shape
+// to Anthropic's Messages API.
+package anthropic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/usage"
+)
+
+const apiVersion = "2023-06-01"
+
+// Adapter talks to Anthropic's /v1/messages endpoint.
+type Adapter struct {
+	BaseURL string
+	APIKey  string
+}
+
+// New builds an Adapter for the given upstream base URL and API key.
+func New(baseURL, apiKey string) *Adapter {
+	return &Adapter{BaseURL: baseURL, APIKey: apiKey}
+}
+
+type messagesRequest struct {
+	Model     string           `json:"model"`
+	System    string           `json:"system,omitempty"`
+	Messages  []models.Message `json:"messages"`
+	MaxTokens int              `json:"max_tokens"`
+	Stream    bool             `json:"stream,omitempty"`
+}
+
+type messagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+}
+
+// Translate maps the canonical request onto Anthropic's Messages API: the
+// system message (if any) is hoisted to the top-level `system` field since
+// Anthropic does not accept a "system" role inside messages.
+func (a *Adapter) Translate(req models.Request) (*http.Request, error) {
+	var system string
+	messages := make([]models.Message, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		if m.Role == "system" {
+			system = m.Content
+			continue
+		}
+		messages = append(messages, m)
+	}
+
+	maxTokens := req.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 1024
+	}
+
+	body, err := json.Marshal(messagesRequest{
+		Model:     req.Model,
+		System:    system,
+		Messages:  messages,
+		MaxTokens: maxTokens,
+		Stream:    req.Stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal anthropic request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, a.BaseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build anthropic request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("anthropic-version", apiVersion)
+	httpReq.Header.Set("x-api-key", a.APIKey)
+	return httpReq, nil
+}
+
+// ParseResponse decodes an Anthropic Messages API response, using the usage
+// package to compute its token accounting from the same body.
+func (a *Adapter) ParseResponse(resp *http.Response) (models.ProviderResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return models.ProviderResponse{}, fmt.Errorf("read anthropic response: %w", err)
+	}
+
+	var parsed messagesResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return models.ProviderResponse{}, fmt.Errorf("decode anthropic response: %w", err)
+	}
+
+	var content strings.Builder
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+
+	tokens, err := usage.FromAnthropicResponse(body)
+	if err != nil {
+		return models.ProviderResponse{}, err
+	}
+
+	return models.ProviderResponse{
+		Content:      content.String(),
+		FinishReason: parsed.StopReason,
+		TokensUsed:   tokens.TotalTokens,
+	}, nil
+}
+
+type streamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+}
+
+// ParseStream translates Anthropic's content_block_delta SSE events into
+// OpenAI-style delta chunks so downstream consumers see one canonical
+// streaming schema.
+func (a *Adapter) ParseStream(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	done := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		payload := strings.TrimPrefix(line, "data: ")
+
+		var evt streamEvent
+		if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+			return fmt.Errorf("malformed anthropic stream event: %w", err)
+		}
+
+		switch evt.Type {
+		case "content_block_delta":
+			if evt.Delta.Type != "text_delta" {
+				continue
+			}
+			chunk := fmt.Sprintf(`{"choices":[{"index":0,"delta":{"content":%q},"finish_reason":null}]}`, evt.Delta.Text)
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", chunk); err != nil {
+				return err
+			}
+		case "message_stop":
+			if _, err := fmt.Fprint(w, "data: [DONE]\n\n"); err != nil {
+				return err
+			}
+			done = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !done {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
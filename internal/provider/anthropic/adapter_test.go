@@ -0,0 +1,118 @@
+package anthropic
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+func TestAdapterTranslateHoistsSystemMessage(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = make([]byte, r.ContentLength)
+		r.Body.Read(body)
+	}))
+	defer server.Close()
+
+	a := New(server.URL, "test-key")
+	httpReq, err := a.Translate(models.Request{
+		Model: "claude-3",
+		Messages: []models.Message{
+			{Role: "system", Content: "be concise"},
+			{Role: "user", Content: "hi"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if httpReq.URL.String() != server.URL+"/v1/messages" {
+		t.Fatalf("unexpected URL: %s", httpReq.URL.String())
+	}
+	if httpReq.Header.Get("x-api-key") != "test-key" {
+		t.Fatalf("expected x-api-key header to be set, got %q", httpReq.Header.Get("x-api-key"))
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.Contains(string(body), `"system":"be concise"`) {
+		t.Fatalf("expected system message to be hoisted to top-level system field, got %s", body)
+	}
+	if strings.Contains(string(body), `"role":"system"`) {
+		t.Fatalf("expected system message to be removed from messages, got %s", body)
+	}
+}
+
+func TestAdapterTranslateDefaultsMaxTokens(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = make([]byte, r.ContentLength)
+		r.Body.Read(body)
+	}))
+	defer server.Close()
+
+	a := New(server.URL, "")
+	httpReq, err := a.Translate(models.Request{Model: "claude-3", Messages: []models.Message{{Role: "user", Content: "hi"}}})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.Contains(string(body), `"max_tokens":1024`) {
+		t.Fatalf("expected default max_tokens of 1024 when unset, got %s", body)
+	}
+}
+
+func TestAdapterParseResponse(t *testing.T) {
+	body := `{"content":[{"type":"text","text":"hello"}],"stop_reason":"end_turn","usage":{"input_tokens":4,"output_tokens":2}}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	a := New("http://example.invalid", "")
+	parsed, err := a.ParseResponse(resp)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if parsed.Content != "hello" {
+		t.Fatalf("unexpected content: %q", parsed.Content)
+	}
+	if parsed.TokensUsed != 6 {
+		t.Fatalf("expected input+output tokens, got %d", parsed.TokensUsed)
+	}
+}
+
+func TestAdapterParseStreamTranslatesToCanonicalSchema(t *testing.T) {
+	input := strings.NewReader("data: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"hi\"}}\n\ndata: {\"type\":\"message_stop\"}\n\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"content":"hi"`) {
+		t.Fatalf("expected translated delta chunk, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "[DONE]") {
+		t.Fatalf("expected [DONE] on message_stop, got %q", out.String())
+	}
+}
+
+func TestAdapterParseStreamMissingStopIsUnexpectedEOF(t *testing.T) {
+	input := strings.NewReader("data: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"hi\"}}\n\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ParseRetryAfter parses a Retry-After header value, which per RFC 9110 may
+// be either a number of seconds or an HTTP-date.
+func ParseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// FullJitterBackoff computes an exponential backoff with full jitter:
+// sleep = rand(0, min(cap, base*2^attempt)). Used when a provider doesn't
+// give us a Retry-After to work with.
+func FullJitterBackoff(base, cap time.Duration, attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	upper := float64(base) * math.Pow(2, float64(attempt))
+	if upper > float64(cap) {
+		upper = float64(cap)
+	}
+	if upper <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Float64() * upper)
+}
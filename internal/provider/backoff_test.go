@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	d, ok := ParseRetryAfter("5")
+	if !ok {
+		t.Fatal("expected a numeric Retry-After to parse")
+	}
+	if d != 5*time.Second {
+		t.Fatalf("expected 5s, got %v", d)
+	}
+}
+
+func TestParseRetryAfterNegativeSecondsClampToZero(t *testing.T) {
+	d, ok := ParseRetryAfter("-5")
+	if !ok {
+		t.Fatal("expected a numeric Retry-After to parse")
+	}
+	if d != 0 {
+		t.Fatalf("expected negative seconds to clamp to 0, got %v", d)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	d, ok := ParseRetryAfter(future)
+	if !ok {
+		t.Fatal("expected an HTTP-date Retry-After to parse")
+	}
+	if d <= 0 || d > 11*time.Second {
+		t.Fatalf("expected roughly 10s remaining, got %v", d)
+	}
+}
+
+func TestParseRetryAfterEmptyIsNotOK(t *testing.T) {
+	_, ok := ParseRetryAfter("")
+	if ok {
+		t.Fatal("expected an empty Retry-After to report not-ok")
+	}
+}
+
+func TestParseRetryAfterGarbageIsNotOK(t *testing.T) {
+	_, ok := ParseRetryAfter("not-a-date-or-number")
+	if ok {
+		t.Fatal("expected an unparseable Retry-After to report not-ok")
+	}
+}
+
+func TestFullJitterBackoffNeverExceedsCap(t *testing.T) {
+	base := 500 * time.Millisecond
+	cap := 5 * time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		d := FullJitterBackoff(base, cap, attempt)
+		if d > cap {
+			t.Fatalf("attempt %d: expected backoff to be capped at %v, got %v", attempt, cap, d)
+		}
+		if d < 0 {
+			t.Fatalf("attempt %d: expected non-negative backoff, got %v", attempt, d)
+		}
+	}
+}
+
+func TestFullJitterBackoffNegativeAttemptTreatedAsZero(t *testing.T) {
+	base := 500 * time.Millisecond
+	cap := 5 * time.Second
+
+	d := FullJitterBackoff(base, cap, -1)
+	if d > base {
+		t.Fatalf("expected a negative attempt to behave like attempt 0 (upper bound %v), got %v", base, d)
+	}
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BreakerState is one of the three standard circuit breaker states.
+type BreakerState int
+
+const (
+	Closed BreakerState = iota
+	Open
+	HalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+type breakerEntry struct {
+	state            BreakerState
+	consecutiveFails int
+	openedAt         time.Time
+	coolDown         time.Duration
+}
+
+// CircuitBreaker trips per provider after consecutive failures within a
+// rolling window, and admits a single probe request once the cool-down
+// elapses before fully closing again.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	entries          map[string]*breakerEntry
+	failureThreshold int
+}
+
+// NewCircuitBreaker builds a CircuitBreaker that opens after
+// failureThreshold consecutive failures.
+func NewCircuitBreaker(failureThreshold int) *CircuitBreaker {
+	return &CircuitBreaker{
+		entries:          make(map[string]*breakerEntry),
+		failureThreshold: failureThreshold,
+	}
+}
+
+func (b *CircuitBreaker) entryFor(id string) *breakerEntry {
+	e, ok := b.entries[id]
+	if !ok {
+		e = &breakerEntry{state: Closed}
+		b.entries[id] = e
+	}
+	return e
+}
+
+// Allow reports whether a call to provider id may be attempted right now.
+// Open transitions to HalfOpen (admitting exactly one probe) once its
+// cool-down has elapsed; while HalfOpen, further calls are refused until
+// that probe resolves.
+func (b *CircuitBreaker) Allow(id string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e := b.entryFor(id)
+	switch e.state {
+	case Closed:
+		return true
+	case Open:
+		if time.Now().Before(e.openedAt.Add(e.coolDown)) {
+			return false
+		}
+		e.state = HalfOpen
+		return true
+	default: // HalfOpen: a probe is already in flight
+		return false
+	}
+}
+
+// RecordSuccess closes the breaker for id, whether it was already closed or
+// the in-flight probe succeeded.
+func (b *CircuitBreaker) RecordSuccess(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e := b.entryFor(id)
+	e.state = Closed
+	e.consecutiveFails = 0
+}
+
+// RecordFailure records a failed call for id. coolDown is the duration the
+// breaker should stay open if this failure trips (or re-trips) it -
+// typically derived from Retry-After or FullJitterBackoff.
+func (b *CircuitBreaker) RecordFailure(requestID, id string, coolDown time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e := b.entryFor(id)
+	e.consecutiveFails++
+
+	switch e.state {
+	case HalfOpen:
+		// The probe failed: re-open immediately, no need to re-count.
+		e.state = Open
+		e.openedAt = time.Now()
+		e.coolDown = coolDown
+		logBreakerOpen(requestID, id, e.consecutiveFails, coolDown)
+	case Closed:
+		if e.consecutiveFails >= b.failureThreshold {
+			e.state = Open
+			e.openedAt = time.Now()
+			e.coolDown = coolDown
+			logBreakerOpen(requestID, id, e.consecutiveFails, coolDown)
+		}
+	}
+}
+
+func logBreakerOpen(requestID, id string, consecutiveFails int, coolDown time.Duration) {
+	log.WithFields(log.Fields{
+		"request_id": requestID,
+		"provider":   id,
+		"fails":      consecutiveFails,
+		"cool_down":  coolDown.String(),
+		"event":      "breaker_open",
+	}).Warn("Circuit breaker opened")
+}
+
+// BreakerSnapshot describes a provider's breaker state for the admin API.
+type BreakerSnapshot struct {
+	State            string     `json:"state"`
+	ConsecutiveFails int        `json:"consecutive_fails"`
+	CoolDownUntil    *time.Time `json:"cool_down_until,omitempty"`
+}
+
+// Status returns the current breaker state for id.
+func (b *CircuitBreaker) Status(id string) BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.entries[id]
+	if !ok {
+		return BreakerSnapshot{State: Closed.String()}
+	}
+
+	snap := BreakerSnapshot{
+		State:            e.state.String(),
+		ConsecutiveFails: e.consecutiveFails,
+	}
+	if e.state == Open {
+		coolDown := e.openedAt.Add(e.coolDown)
+		snap.CoolDownUntil = &coolDown
+	}
+	return snap
+}
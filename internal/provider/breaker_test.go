@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStaysClosedBelowThreshold(t *testing.T) {
+	b := NewCircuitBreaker(3)
+
+	b.RecordFailure("req1", "openai", time.Second)
+	b.RecordFailure("req1", "openai", time.Second)
+
+	if !b.Allow("openai") {
+		t.Fatal("expected breaker to still allow calls below the failure threshold")
+	}
+	if got := b.Status("openai").State; got != Closed.String() {
+		t.Fatalf("expected state %q, got %q", Closed, got)
+	}
+}
+
+func TestCircuitBreakerOpensAtThreshold(t *testing.T) {
+	b := NewCircuitBreaker(3)
+
+	for i := 0; i < 3; i++ {
+		b.RecordFailure("req1", "openai", time.Minute)
+	}
+
+	if b.Allow("openai") {
+		t.Fatal("expected breaker to refuse calls once it opens")
+	}
+	if got := b.Status("openai").State; got != Open.String() {
+		t.Fatalf("expected state %q, got %q", Open, got)
+	}
+}
+
+func TestCircuitBreakerHalfOpensAfterCoolDown(t *testing.T) {
+	b := NewCircuitBreaker(1)
+
+	b.RecordFailure("req1", "openai", 10*time.Millisecond)
+	if b.Allow("openai") {
+		t.Fatal("expected breaker to refuse calls immediately after opening")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.Allow("openai") {
+		t.Fatal("expected breaker to admit a single probe once its cool-down elapses")
+	}
+	if got := b.Status("openai").State; got != HalfOpen.String() {
+		t.Fatalf("expected state %q after the cool-down elapses, got %q", HalfOpen, got)
+	}
+
+	// A second caller must not get its own probe while one is in flight.
+	if b.Allow("openai") {
+		t.Fatal("expected breaker to refuse a second concurrent probe while half-open")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeSuccessCloses(t *testing.T) {
+	b := NewCircuitBreaker(1)
+
+	b.RecordFailure("req1", "openai", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	b.Allow("openai") // transitions to HalfOpen and consumes the probe slot
+
+	b.RecordSuccess("openai")
+
+	if got := b.Status("openai").State; got != Closed.String() {
+		t.Fatalf("expected a successful probe to close the breaker, got %q", got)
+	}
+	if !b.Allow("openai") {
+		t.Fatal("expected breaker to allow calls again once closed")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeFailureReopens(t *testing.T) {
+	b := NewCircuitBreaker(1)
+
+	b.RecordFailure("req1", "openai", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	b.Allow("openai") // transitions to HalfOpen and consumes the probe slot
+
+	b.RecordFailure("req1", "openai", time.Minute)
+
+	if got := b.Status("openai").State; got != Open.String() {
+		t.Fatalf("expected a failed probe to re-open the breaker, got %q", got)
+	}
+	if b.Allow("openai") {
+		t.Fatal("expected breaker to refuse calls immediately after re-opening")
+	}
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+const (
+	breakerFailureThreshold = 5
+	backoffBase             = 500 * time.Millisecond
+	backoffCap              = 30 * time.Second
+)
+
+// Result is what a provider call returns once it has (possibly after
+// retrying across providers) produced a response.
+type Result struct {
+	Content    string
+	Provider   string
+	Attempts   int
+	AttemptLog []models.Attempt
+	TokensUsed int
+}
+
+// Client routes chat completion requests across the configured providers,
+// failing over to the next healthy candidate on a retryable error. Each
+// provider is dispatched through the Adapter registered for it, so Client
+// itself stays provider-agnostic. Every call is wrapped in a per-provider
+// circuit breaker and rate limiter.
+type Client struct {
+	Registry    *Registry
+	Router      *Router
+	Health      *HealthTracker
+	Breaker     *CircuitBreaker
+	RateLimiter *RateLimiterSet
+	Adapters    map[string]Adapter
+	HTTPClient  *http.Client
+}
+
+// NewClient builds a Client over the given registry, health tracker and
+// adapter set. adapters is keyed by ProviderConfig.ID, since each provider
+// instance carries its own base URL and API key baked into its Adapter.
+func NewClient(registry *Registry, health *HealthTracker, adapters map[string]Adapter) *Client {
+	limiters := NewRateLimiterSet(registry.Ordered())
+
+	return &Client{
+		Registry:    registry,
+		Router:      NewRouter(registry, health, limiters),
+		Health:      health,
+		Breaker:     NewCircuitBreaker(breakerFailureThreshold),
+		RateLimiter: limiters,
+		Adapters:    adapters,
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *Client) adapterFor(p *ProviderConfig) (Adapter, error) {
+	a, ok := c.Adapters[p.ID]
+	if !ok {
+		return nil, fmt.Errorf("no adapter registered for provider %s", p.ID)
+	}
+	return a, nil
+}
+
+// Call performs a non-streaming chat completion, trying providers in router
+// order until one succeeds or the candidates are exhausted.
+func (c *Client) Call(ctx context.Context, req models.Request) (*Result, error) {
+	requestID, _ := ctx.Value("request_id").(string)
+
+	candidates, err := c.Router.Candidates(req.PreferredProvider)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no healthy providers available")
+	}
+
+	lastErr := fmt.Errorf("all provider breakers open")
+	var attemptLog []models.Attempt
+	attemptNum := 0
+
+	for _, p := range candidates {
+		if !c.Breaker.Allow(p.ID) {
+			continue
+		}
+
+		attemptNum++
+		startedAt := time.Now()
+		c.RateLimiter.Consume(p.ID)
+
+		parsed, statusCode, retryAfter, err := c.callOnce(ctx, p, req)
+
+		attempt := models.Attempt{
+			RequestID:     requestID,
+			AttemptNumber: attemptNum,
+			Provider:      p.ID,
+			StartedAt:     startedAt,
+			EndedAt:       time.Now(),
+		}
+
+		if err != nil {
+			attempt.Status = "failed"
+			attempt.ErrorMessage = err.Error()
+			attempt.LatencyMs = time.Since(startedAt).Milliseconds()
+			attemptLog = append(attemptLog, attempt)
+
+			coolDown := backoffFor(statusCode, retryAfter, attempt.AttemptNumber-1)
+			c.Health.MarkFailure(p.ID, statusCode)
+			c.Breaker.RecordFailure(requestID, p.ID, coolDown)
+			if statusCode == http.StatusTooManyRequests {
+				c.RateLimiter.Throttle(p.ID)
+			}
+
+			log.WithFields(log.Fields{
+				"request_id": requestID,
+				"provider":   p.ID,
+				"attempt":    attemptNum,
+				"status":     statusCode,
+				"event":      "provider_attempt_failed",
+			}).Warn("Provider attempt failed")
+
+			lastErr = err
+			continue
+		}
+
+		attempt.Status = "success"
+		attempt.LatencyMs = time.Since(startedAt).Milliseconds()
+		attempt.TokensUsed = parsed.TokensUsed
+		attemptLog = append(attemptLog, attempt)
+
+		c.Health.MarkSuccess(p.ID)
+		c.Breaker.RecordSuccess(p.ID)
+		c.RateLimiter.Recover(p.ID)
+
+		return &Result{
+			Content:    parsed.Content,
+			Provider:   p.ID,
+			Attempts:   attemptNum,
+			AttemptLog: attemptLog,
+			TokensUsed: parsed.TokensUsed,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("all providers exhausted: %w", lastErr)
+}
+
+func (c *Client) callOnce(ctx context.Context, p *ProviderConfig, req models.Request) (models.ProviderResponse, int, time.Duration, error) {
+	adapter, err := c.adapterFor(p)
+	if err != nil {
+		return models.ProviderResponse{}, 0, 0, err
+	}
+
+	httpReq, err := adapter.Translate(req)
+	if err != nil {
+		return models.ProviderResponse{}, 0, 0, fmt.Errorf("translate request for %s: %w", p.ID, err)
+	}
+	httpReq = httpReq.WithContext(ctx)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return models.ProviderResponse{}, 0, 0, fmt.Errorf("call provider %s: %w", p.ID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retryAfter, _ := ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return models.ProviderResponse{}, resp.StatusCode, retryAfter, fmt.Errorf("provider %s returned status %d", p.ID, resp.StatusCode)
+	}
+
+	parsed, err := adapter.ParseResponse(resp)
+	if err != nil {
+		return models.ProviderResponse{}, resp.StatusCode, 0, fmt.Errorf("parse response from %s: %w", p.ID, err)
+	}
+	return parsed, resp.StatusCode, 0, nil
+}
+
+// backoffFor picks how long a provider should cool down after a failure:
+// the upstream's own Retry-After when it gave us one (429s usually do),
+// otherwise an exponential backoff with full jitter.
+func backoffFor(statusCode int, retryAfter time.Duration, attempt int) time.Duration {
+	if statusCode == http.StatusTooManyRequests && retryAfter > 0 {
+		return retryAfter
+	}
+	return FullJitterBackoff(backoffBase, backoffCap, attempt)
+}
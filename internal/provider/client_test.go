@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+func newTestClient(t *testing.T, configs []ProviderConfig) *Client {
+	t.Helper()
+
+	adapters, err := BuildAdapters(configs)
+	if err != nil {
+		t.Fatalf("build adapters: %v", err)
+	}
+
+	registry := NewRegistry(configs)
+	health := NewHealthTracker(time.Second, time.Minute)
+	return NewClient(registry, health, adapters)
+}
+
+func openAIOKServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"content":"hi from fallback"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`))
+	}))
+}
+
+func TestClientCallFallsBackPastAuthFailure(t *testing.T) {
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorized.Close()
+
+	ok := openAIOKServer(t)
+	defer ok.Close()
+
+	client := newTestClient(t, []ProviderConfig{
+		{ID: "first", Adapter: "openai", BaseURL: unauthorized.URL, Priority: 0},
+		{ID: "second", Adapter: "openai", BaseURL: ok.URL, Priority: 1},
+	})
+
+	result, err := client.Call(context.Background(), models.Request{Model: "gpt-4"})
+	if err != nil {
+		t.Fatalf("expected Call to fall back to the healthy provider, got error: %v", err)
+	}
+	if result.Provider != "second" {
+		t.Fatalf("expected the fallback provider %q to serve the request, got %q", "second", result.Provider)
+	}
+	if result.Content != "hi from fallback" {
+		t.Fatalf("unexpected content: %q", result.Content)
+	}
+
+	// The first provider's 401 should have permanently marked it down, even
+	// though the overall call succeeded via the second provider.
+	if client.Health.IsHealthy("first") {
+		t.Fatal("expected the 401 provider to be marked permanently unhealthy")
+	}
+}
+
+func TestClientCallAllProvidersFail(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	client := newTestClient(t, []ProviderConfig{
+		{ID: "only", Adapter: "openai", BaseURL: failing.URL, Priority: 0},
+	})
+
+	_, err := client.Call(context.Background(), models.Request{Model: "gpt-4"})
+	if err == nil {
+		t.Fatal("expected an error when every candidate fails")
+	}
+}
+
+func TestClientCallRecordsAttemptLog(t *testing.T) {
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorized.Close()
+
+	ok := openAIOKServer(t)
+	defer ok.Close()
+
+	client := newTestClient(t, []ProviderConfig{
+		{ID: "first", Adapter: "openai", BaseURL: unauthorized.URL, Priority: 0},
+		{ID: "second", Adapter: "openai", BaseURL: ok.URL, Priority: 1},
+	})
+
+	result, err := client.Call(context.Background(), models.Request{Model: "gpt-4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.AttemptLog) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %d", len(result.AttemptLog))
+	}
+	if result.AttemptLog[0].Provider != "first" || result.AttemptLog[0].Status != "failed" {
+		t.Fatalf("expected first attempt to record the failed provider, got %+v", result.AttemptLog[0])
+	}
+	if result.AttemptLog[1].Provider != "second" || result.AttemptLog[1].Status != "success" {
+		t.Fatalf("expected second attempt to record the successful provider, got %+v", result.AttemptLog[1])
+	}
+}
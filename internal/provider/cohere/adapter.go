@@ -0,0 +1,150 @@
+// Package cohere adapts the gateway's canonical request/response shape to
+// Cohere's /v1/chat API.
+package cohere
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+// Adapter talks to Cohere's /v1/chat endpoint.
+type Adapter struct {
+	BaseURL string
+	APIKey  string
+}
+
+// New builds an Adapter for the given upstream base URL and API key.
+func New(baseURL, apiKey string) *Adapter {
+	return &Adapter{BaseURL: baseURL, APIKey: apiKey}
+}
+
+type chatHistoryEntry struct {
+	Role    string `json:"role"` // "USER", "CHATBOT", "SYSTEM"
+	Message string `json:"message"`
+}
+
+type chatRequest struct {
+	Model       string             `json:"model"`
+	Message     string             `json:"message"`
+	ChatHistory []chatHistoryEntry `json:"chat_history,omitempty"`
+	Stream      bool               `json:"stream,omitempty"`
+}
+
+type chatResponse struct {
+	Text string `json:"text"`
+	Meta struct {
+		Tokens struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"tokens"`
+	} `json:"meta"`
+	FinishReason string `json:"finish_reason"`
+}
+
+func cohereRole(msgRole string) string {
+	switch msgRole {
+	case "assistant":
+		return "CHATBOT"
+	case "system":
+		return "SYSTEM"
+	default:
+		return "USER"
+	}
+}
+
+// Translate maps the canonical request onto Cohere's chat API: the final
+// message becomes `message`, everything before it becomes `chat_history`.
+func (a *Adapter) Translate(req models.Request) (*http.Request, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("cohere: request has no messages")
+	}
+
+	last := req.Messages[len(req.Messages)-1]
+	history := make([]chatHistoryEntry, 0, len(req.Messages)-1)
+	for _, m := range req.Messages[:len(req.Messages)-1] {
+		history = append(history, chatHistoryEntry{Role: cohereRole(m.Role), Message: m.Content})
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model:       req.Model,
+		Message:     last.Content,
+		ChatHistory: history,
+		Stream:      req.Stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal cohere request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, a.BaseURL+"/v1/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build cohere request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+a.APIKey)
+	return httpReq, nil
+}
+
+// ParseResponse decodes a Cohere chat response.
+func (a *Adapter) ParseResponse(resp *http.Response) (models.ProviderResponse, error) {
+	var parsed chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return models.ProviderResponse{}, fmt.Errorf("decode cohere response: %w", err)
+	}
+
+	return models.ProviderResponse{
+		Content:      parsed.Text,
+		FinishReason: parsed.FinishReason,
+		TokensUsed:   parsed.Meta.Tokens.InputTokens + parsed.Meta.Tokens.OutputTokens,
+	}, nil
+}
+
+type streamEvent struct {
+	EventType string `json:"event_type"` // "text-generation", "stream-end"
+	Text      string `json:"text"`
+}
+
+// ParseStream translates Cohere's event_type stream into OpenAI-style delta
+// chunks so downstream consumers see one canonical streaming schema.
+func (a *Adapter) ParseStream(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	done := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var evt streamEvent
+		if err := json.Unmarshal([]byte(line), &evt); err != nil {
+			return fmt.Errorf("malformed cohere stream event: %w", err)
+		}
+
+		switch evt.EventType {
+		case "text-generation":
+			chunk := fmt.Sprintf(`{"choices":[{"index":0,"delta":{"content":%q},"finish_reason":null}]}`, evt.Text)
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", chunk); err != nil {
+				return err
+			}
+		case "stream-end":
+			if _, err := fmt.Fprint(w, "data: [DONE]\n\n"); err != nil {
+				return err
+			}
+			done = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !done {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cohere
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+func TestAdapterTranslateSplitsHistoryFromLastMessage(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		w.Write([]byte(`{"text":"ok"}`))
+	}))
+	defer server.Close()
+
+	a := New(server.URL, "test-key")
+	httpReq, err := a.Translate(models.Request{
+		Model: "command-r",
+		Messages: []models.Message{
+			{Role: "user", Content: "first"},
+			{Role: "assistant", Content: "second"},
+			{Role: "user", Content: "third"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if httpReq.URL.String() != server.URL+"/v1/chat" {
+		t.Fatalf("unexpected URL: %s", httpReq.URL.String())
+	}
+	if httpReq.Header.Get("Authorization") != "Bearer test-key" {
+		t.Fatalf("expected Authorization header to be set, got %q", httpReq.Header.Get("Authorization"))
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.Contains(string(body), `"message":"third"`) {
+		t.Fatalf("expected the last message to become the top-level message, got %s", body)
+	}
+	if !strings.Contains(string(body), `"role":"CHATBOT","message":"second"`) {
+		t.Fatalf("expected earlier messages to be mapped into chat_history, got %s", body)
+	}
+}
+
+func TestAdapterTranslateRejectsEmptyMessages(t *testing.T) {
+	a := New("http://example.invalid", "")
+	if _, err := a.Translate(models.Request{Model: "command-r"}); err == nil {
+		t.Fatal("expected an error when the request has no messages")
+	}
+}
+
+func TestAdapterParseResponse(t *testing.T) {
+	body := `{"text":"hello","finish_reason":"COMPLETE","meta":{"tokens":{"input_tokens":3,"output_tokens":2}}}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	a := New("http://example.invalid", "")
+	parsed, err := a.ParseResponse(resp)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if parsed.Content != "hello" {
+		t.Fatalf("unexpected content: %q", parsed.Content)
+	}
+	if parsed.TokensUsed != 5 {
+		t.Fatalf("expected input+output tokens, got %d", parsed.TokensUsed)
+	}
+}
+
+func TestAdapterParseStreamTranslatesToCanonicalSchema(t *testing.T) {
+	input := strings.NewReader("{\"event_type\":\"text-generation\",\"text\":\"hi\"}\n{\"event_type\":\"stream-end\"}\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"content":"hi"`) {
+		t.Fatalf("expected translated delta chunk, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "[DONE]") {
+		t.Fatalf("expected [DONE] on stream-end, got %q", out.String())
+	}
+}
+
+func TestAdapterParseStreamRejectsMalformedEvent(t *testing.T) {
+	input := strings.NewReader("{not json}\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err == nil {
+		t.Fatal("expected malformed event to be rejected")
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProviderConfig describes a single upstream LLM provider entry as loaded
+// from config and handed to the Registry.
+type ProviderConfig struct {
+	ID           string `yaml:"id"`
+	Name         string `yaml:"name"`
+	Adapter      string `yaml:"adapter"` // "openai", "anthropic", "cohere"
+	BaseURL      string `yaml:"base_url"`
+	APIKey       string `yaml:"api_key"`
+	Priority     int    `yaml:"priority"` // lower runs first
+	Weight       int    `yaml:"weight"`   // used to break priority ties
+	RateLimitRPS int    `yaml:"rate_limit_rps"`
+}
+
+// Config is the top-level provider configuration document.
+type Config struct {
+	Providers []ProviderConfig `yaml:"providers"`
+}
+
+// LoadConfig reads the provider list from a YAML file at path and layers
+// environment variable overrides on top, so API keys never need to live in
+// the config file itself.
+//
+// For a provider with ID "openai", the following env vars are recognized:
+//
+//	PROVIDER_OPENAI_API_KEY, PROVIDER_OPENAI_BASE_URL
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read provider config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse provider config: %w", err)
+	}
+
+	for i := range cfg.Providers {
+		applyEnvOverrides(&cfg.Providers[i])
+	}
+
+	return &cfg, nil
+}
+
+func applyEnvOverrides(p *ProviderConfig) {
+	prefix := "PROVIDER_" + strings.ToUpper(p.ID) + "_"
+
+	if v := os.Getenv(prefix + "API_KEY"); v != "" {
+		p.APIKey = v
+	}
+	if v := os.Getenv(prefix + "BASE_URL"); v != "" {
+		p.BaseURL = v
+	}
+	if v := os.Getenv(prefix + "PRIORITY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			p.Priority = n
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider/anthropic"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider/cohere"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/provider/openai"
+)
+
+// BuildAdapters constructs one Adapter per configured provider, keyed by
+// provider ID, based on each entry's Adapter field.
+func BuildAdapters(configs []ProviderConfig) (map[string]Adapter, error) {
+	adapters := make(map[string]Adapter, len(configs))
+
+	for _, p := range configs {
+		switch p.Adapter {
+		case "openai":
+			adapters[p.ID] = openai.New(p.BaseURL, p.APIKey)
+		case "anthropic":
+			adapters[p.ID] = anthropic.New(p.BaseURL, p.APIKey)
+		case "cohere":
+			adapters[p.ID] = cohere.New(p.BaseURL, p.APIKey)
+		default:
+			return nil, fmt.Errorf("provider %s: unknown adapter %q", p.ID, p.Adapter)
+		}
+	}
+
+	return adapters, nil
+}
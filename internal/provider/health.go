@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// healthState tracks the availability of a single provider.
+type healthState struct {
+	permanentlyDown  bool // 401/403: stays down until the next config reload
+	consecutiveFails int
+	coolDownUntil    time.Time
+}
+
+// HealthTracker records provider failures and decides whether a provider is
+// currently eligible to receive traffic.
+//
+// Auth failures (401/403) are treated as permanent until the next config
+// reload: a misconfigured API key will not fix itself on a timer, so
+// retrying it only wastes time and risks tripping upstream lockouts.
+// Throttling and transient errors (429/5xx) instead recover after an
+// exponential cool-down, since those conditions are expected to clear on
+// their own.
+type HealthTracker struct {
+	mu       sync.Mutex
+	states   map[string]*healthState
+	baseCool time.Duration
+	maxCool  time.Duration
+}
+
+// NewHealthTracker creates a HealthTracker with the given base and max
+// cool-down durations used for the exponential backoff applied to
+// transient failures.
+func NewHealthTracker(baseCool, maxCool time.Duration) *HealthTracker {
+	return &HealthTracker{
+		states:   make(map[string]*healthState),
+		baseCool: baseCool,
+		maxCool:  maxCool,
+	}
+}
+
+func (h *HealthTracker) stateFor(id string) *healthState {
+	s, ok := h.states[id]
+	if !ok {
+		s = &healthState{}
+		h.states[id] = s
+	}
+	return s
+}
+
+// IsHealthy reports whether the provider may currently be routed to.
+func (h *HealthTracker) IsHealthy(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.states[id]
+	if !ok {
+		return true
+	}
+	if s.permanentlyDown {
+		return false
+	}
+	return time.Now().After(s.coolDownUntil)
+}
+
+// MarkFailure records a failed call and updates the provider's health based
+// on the HTTP status code observed.
+func (h *HealthTracker) MarkFailure(id string, statusCode int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.stateFor(id)
+	s.consecutiveFails++
+
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		s.permanentlyDown = true
+		return
+	}
+
+	cool := time.Duration(float64(h.baseCool) * math.Pow(2, float64(s.consecutiveFails-1)))
+	if cool > h.maxCool {
+		cool = h.maxCool
+	}
+	s.coolDownUntil = time.Now().Add(cool)
+}
+
+// MarkSuccess resets a provider's failure streak after a successful call.
+func (h *HealthTracker) MarkSuccess(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.stateFor(id)
+	s.consecutiveFails = 0
+	s.coolDownUntil = time.Time{}
+}
+
+// Reload clears permanent lockouts, e.g. after an operator rotates a
+// misconfigured API key and reloads config.
+func (h *HealthTracker) Reload() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, s := range h.states {
+		s.permanentlyDown = false
+		s.consecutiveFails = 0
+		s.coolDownUntil = time.Time{}
+	}
+}
+
+// Snapshot describes a provider's current health for the admin API.
+type Snapshot struct {
+	Healthy          bool       `json:"healthy"`
+	PermanentlyDown  bool       `json:"permanently_down"`
+	ConsecutiveFails int        `json:"consecutive_fails"`
+	CoolDownUntil    *time.Time `json:"cool_down_until,omitempty"`
+}
+
+// Snapshot returns the current health state of a provider for reporting.
+func (h *HealthTracker) Status(id string) Snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.states[id]
+	if !ok {
+		return Snapshot{Healthy: true}
+	}
+	snap := Snapshot{
+		Healthy:          !s.permanentlyDown && time.Now().After(s.coolDownUntil),
+		PermanentlyDown:  s.permanentlyDown,
+		ConsecutiveFails: s.consecutiveFails,
+	}
+	if !s.coolDownUntil.IsZero() {
+		coolDown := s.coolDownUntil
+		snap.CoolDownUntil = &coolDown
+	}
+	return snap
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHealthTrackerPermanentlyDownOnAuthFailure(t *testing.T) {
+	h := NewHealthTracker(10*time.Millisecond, time.Second)
+
+	h.MarkFailure("openai", http.StatusUnauthorized)
+	if h.IsHealthy("openai") {
+		t.Fatal("expected provider to be unhealthy after a 401")
+	}
+
+	// A permanent lockout does not clear on its own, unlike a cool-down.
+	time.Sleep(20 * time.Millisecond)
+	if h.IsHealthy("openai") {
+		t.Fatal("expected provider to remain permanently down after waiting out what would be a cool-down")
+	}
+
+	h.Reload()
+	if !h.IsHealthy("openai") {
+		t.Fatal("expected Reload to clear a permanent lockout")
+	}
+}
+
+func TestHealthTrackerPermanentlyDownOnForbidden(t *testing.T) {
+	h := NewHealthTracker(10*time.Millisecond, time.Second)
+
+	h.MarkFailure("openai", http.StatusForbidden)
+	if h.IsHealthy("openai") {
+		t.Fatal("expected provider to be unhealthy after a 403")
+	}
+}
+
+func TestHealthTrackerTransientFailureRecoversAfterCoolDown(t *testing.T) {
+	h := NewHealthTracker(10*time.Millisecond, time.Second)
+
+	h.MarkFailure("openai", http.StatusTooManyRequests)
+	if h.IsHealthy("openai") {
+		t.Fatal("expected provider to be unhealthy immediately after a 429")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !h.IsHealthy("openai") {
+		t.Fatal("expected a transient failure to recover once its cool-down elapses")
+	}
+}
+
+func TestHealthTrackerCoolDownBacksOffExponentially(t *testing.T) {
+	h := NewHealthTracker(10*time.Millisecond, time.Second)
+
+	h.MarkFailure("openai", http.StatusTooManyRequests)
+	first := h.Status("openai").CoolDownUntil
+
+	h.MarkFailure("openai", http.StatusTooManyRequests)
+	second := h.Status("openai").CoolDownUntil
+
+	if first == nil || second == nil {
+		t.Fatalf("expected both failures to set a cool-down, got first=%v second=%v", first, second)
+	}
+	if !second.After(*first) {
+		t.Fatalf("expected cool-down to back off further on repeated failures, got first=%v second=%v", first, second)
+	}
+}
+
+func TestHealthTrackerCoolDownCappedAtMax(t *testing.T) {
+	h := NewHealthTracker(time.Second, 2*time.Second)
+
+	for i := 0; i < 10; i++ {
+		h.MarkFailure("openai", http.StatusTooManyRequests)
+	}
+
+	snap := h.Status("openai")
+	if snap.CoolDownUntil == nil {
+		t.Fatal("expected a cool-down to be set")
+	}
+	if time.Until(*snap.CoolDownUntil) > 2*time.Second+100*time.Millisecond {
+		t.Fatalf("expected cool-down to be capped at maxCool, got %v remaining", time.Until(*snap.CoolDownUntil))
+	}
+}
+
+func TestHealthTrackerMarkSuccessResetsFailureStreak(t *testing.T) {
+	h := NewHealthTracker(time.Second, time.Minute)
+
+	h.MarkFailure("openai", http.StatusTooManyRequests)
+	h.MarkSuccess("openai")
+
+	snap := h.Status("openai")
+	if snap.ConsecutiveFails != 0 {
+		t.Fatalf("expected MarkSuccess to reset consecutive fails, got %d", snap.ConsecutiveFails)
+	}
+	if !h.IsHealthy("openai") {
+		t.Fatal("expected provider to be healthy immediately after a success")
+	}
+}
+
+func TestHealthTrackerUnknownProviderIsHealthy(t *testing.T) {
+	h := NewHealthTracker(time.Second, time.Minute)
+
+	if !h.IsHealthy("never-seen") {
+		t.Fatal("expected a provider with no recorded history to be treated as healthy")
+	}
+}
@@ -0,0 +1,141 @@
+// Package openai adapts the gateway's canonical request/response shape to
+// the OpenAI-compatible chat completions API (also used by the repo's mock
+// provider).
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/usage"
+)
+
+// Adapter talks to an OpenAI-compatible /v1/chat/completions endpoint.
+type Adapter struct {
+	BaseURL string
+	APIKey  string
+}
+
+// New builds an Adapter for the given upstream base URL and API key.
+func New(baseURL, apiKey string) *Adapter {
+	return &Adapter{BaseURL: baseURL, APIKey: apiKey}
+}
+
+type chatRequest struct {
+	Model       string           `json:"model"`
+	Messages    []models.Message `json:"messages"`
+	Temperature float64          `json:"temperature,omitempty"`
+	MaxTokens   int              `json:"max_tokens,omitempty"`
+	Stream      bool             `json:"stream,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// Translate builds the OpenAI-shaped chat completions request.
+func (a *Adapter) Translate(req models.Request) (*http.Request, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:       req.Model,
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stream:      req.Stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal openai request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, a.BaseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build openai request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if a.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+a.APIKey)
+	}
+	return httpReq, nil
+}
+
+// ParseResponse decodes an OpenAI chat completion response, using the
+// usage package to pull its token accounting out of the same body.
+func (a *Adapter) ParseResponse(resp *http.Response) (models.ProviderResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return models.ProviderResponse{}, fmt.Errorf("read openai response: %w", err)
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return models.ProviderResponse{}, fmt.Errorf("decode openai response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return models.ProviderResponse{}, fmt.Errorf("openai response has no choices")
+	}
+
+	tokens, err := usage.FromOpenAIResponse(body)
+	if err != nil {
+		return models.ProviderResponse{}, err
+	}
+
+	return models.ProviderResponse{
+		Content:      parsed.Choices[0].Message.Content,
+		FinishReason: parsed.Choices[0].FinishReason,
+		TokensUsed:   tokens.TotalTokens,
+	}, nil
+}
+
+// ParseStream copies the OpenAI SSE stream through unchanged: it is already
+// the canonical schema downstream consumers expect. It scans line by line
+// rather than doing a raw copy, and validates each chunk's JSON before
+// forwarding it, so a connection that closes mid-chunk (a broken delta, no
+// trailing [DONE]) is reported as an error instead of first flushing the
+// truncated fragment to the client and only then failing over.
+func (a *Adapter) ParseStream(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	done := false
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload != line && payload != "[DONE]" {
+			var chunk struct {
+				Choices []struct {
+					Delta struct {
+						Content string `json:"content"`
+					} `json:"delta"`
+				} `json:"choices"`
+			}
+			if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+				return fmt.Errorf("malformed stream chunk: %w", err)
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+			return err
+		}
+		if payload == "[DONE]" {
+			done = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !done {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+func TestAdapterTranslateBuildsChatCompletionsRequest(t *testing.T) {
+	var captured *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r
+		body = make([]byte, r.ContentLength)
+		r.Body.Read(body)
+	}))
+	defer server.Close()
+
+	a := New(server.URL, "sk-test")
+	httpReq, err := a.Translate(models.Request{
+		Model:    "gpt-4",
+		Messages: []models.Message{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if httpReq.URL.String() != server.URL+"/v1/chat/completions" {
+		t.Fatalf("unexpected URL: %s", httpReq.URL.String())
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if captured.Header.Get("Authorization") != "Bearer sk-test" {
+		t.Fatalf("expected Authorization header to be set, got %q", captured.Header.Get("Authorization"))
+	}
+	if !strings.Contains(string(body), `"content":"hi"`) {
+		t.Fatalf("expected request body to carry the message content, got %s", body)
+	}
+}
+
+func TestAdapterParseResponse(t *testing.T) {
+	body := `{"choices":[{"message":{"content":"hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	a := New("http://example.invalid", "")
+	parsed, err := a.ParseResponse(resp)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if parsed.Content != "hello there" {
+		t.Fatalf("unexpected content: %q", parsed.Content)
+	}
+	if parsed.FinishReason != "stop" {
+		t.Fatalf("unexpected finish reason: %q", parsed.FinishReason)
+	}
+	if parsed.TokensUsed != 5 {
+		t.Fatalf("unexpected tokens used: %d", parsed.TokensUsed)
+	}
+}
+
+func TestAdapterParseResponseNoChoicesIsError(t *testing.T) {
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(`{"choices":[]}`))}
+
+	a := New("http://example.invalid", "")
+	if _, err := a.ParseResponse(resp); err == nil {
+		t.Fatal("expected an error when the response has no choices")
+	}
+}
+
+func TestAdapterParseStreamForwardsValidChunks(t *testing.T) {
+	input := strings.NewReader("data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\ndata: [DONE]\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[DONE]") {
+		t.Fatalf("expected [DONE] to be forwarded, got %q", out.String())
+	}
+}
+
+func TestAdapterParseStreamRejectsMalformedChunk(t *testing.T) {
+	input := strings.NewReader("data: {not json}\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err == nil {
+		t.Fatal("expected malformed chunk to be rejected before being forwarded")
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected nothing to be written to the client before validation, got %q", out.String())
+	}
+}
+
+func TestAdapterParseStreamMissingDoneIsUnexpectedEOF(t *testing.T) {
+	input := strings.NewReader("data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n")
+	var out strings.Builder
+
+	a := New("http://example.invalid", "")
+	if err := a.ParseStream(input, &out); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
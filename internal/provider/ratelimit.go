@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	minRefillPerSec   = 0.5
+	throttleFactor    = 0.5
+	recoverPerSuccess = 1.1
+	defaultBucketRPS  = 10
+)
+
+// tokenBucket is a simple token bucket rate limiter whose refill rate
+// shrinks when its provider starts getting throttled, and slowly recovers
+// on continued success.
+type tokenBucket struct {
+	mu            sync.Mutex
+	capacity      float64
+	tokens        float64
+	refillPerSec  float64
+	configuredRPS float64
+	last          time.Time
+}
+
+func newTokenBucket(rps int) *tokenBucket {
+	if rps <= 0 {
+		rps = defaultBucketRPS
+	}
+	return &tokenBucket{
+		capacity:      float64(rps),
+		tokens:        float64(rps),
+		refillPerSec:  float64(rps),
+		configuredRPS: float64(rps),
+		last:          time.Now(),
+	}
+}
+
+func (t *tokenBucket) refill() {
+	now := time.Now()
+	elapsed := now.Sub(t.last).Seconds()
+	t.last = now
+
+	t.tokens += elapsed * t.refillPerSec
+	if t.tokens > t.capacity {
+		t.tokens = t.capacity
+	}
+}
+
+// HasCapacity reports whether a token is currently available, without
+// consuming one. Used to preemptively skip a provider that's being
+// throttled before even attempting a call.
+func (t *tokenBucket) HasCapacity() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refill()
+	return t.tokens >= 1
+}
+
+// Consume takes one token for an in-flight call.
+func (t *tokenBucket) Consume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refill()
+	t.tokens--
+}
+
+// Throttle shrinks the refill rate after observing a 429, so the bucket
+// drains faster and the provider is skipped more readily until it recovers.
+func (t *tokenBucket) Throttle() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refillPerSec *= throttleFactor
+	if t.refillPerSec < minRefillPerSec {
+		t.refillPerSec = minRefillPerSec
+	}
+}
+
+// Recover nudges the refill rate back toward its configured value after a
+// successful call.
+func (t *tokenBucket) Recover() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refillPerSec *= recoverPerSuccess
+	if t.refillPerSec > t.configuredRPS {
+		t.refillPerSec = t.configuredRPS
+	}
+}
+
+// RateLimiterStatus describes a provider's current bucket fill for the
+// admin API.
+type RateLimiterStatus struct {
+	Tokens       float64 `json:"tokens"`
+	Capacity     float64 `json:"capacity"`
+	RefillPerSec float64 `json:"refill_per_sec"`
+}
+
+func (t *tokenBucket) status() RateLimiterStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refill()
+	return RateLimiterStatus{
+		Tokens:       t.tokens,
+		Capacity:     t.capacity,
+		RefillPerSec: t.refillPerSec,
+	}
+}
+
+// RateLimiterSet holds one token bucket per provider, sized from each
+// provider's configured rate limit hint.
+type RateLimiterSet struct {
+	mu      sync.RWMutex
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiterSet builds a bucket per provider in configs.
+func NewRateLimiterSet(configs []*ProviderConfig) *RateLimiterSet {
+	buckets := make(map[string]*tokenBucket, len(configs))
+	for _, p := range configs {
+		buckets[p.ID] = newTokenBucket(p.RateLimitRPS)
+	}
+	return &RateLimiterSet{buckets: buckets}
+}
+
+func (s *RateLimiterSet) bucketFor(id string) *tokenBucket {
+	s.mu.RLock()
+	b, ok := s.buckets[id]
+	s.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok = s.buckets[id]
+	if !ok {
+		b = newTokenBucket(defaultBucketRPS)
+		s.buckets[id] = b
+	}
+	return b
+}
+
+// HasCapacity reports whether provider id currently has a free token.
+func (s *RateLimiterSet) HasCapacity(id string) bool {
+	return s.bucketFor(id).HasCapacity()
+}
+
+// Consume takes one token for provider id.
+func (s *RateLimiterSet) Consume(id string) {
+	s.bucketFor(id).Consume()
+}
+
+// Throttle shrinks provider id's refill rate after a 429.
+func (s *RateLimiterSet) Throttle(id string) {
+	s.bucketFor(id).Throttle()
+}
+
+// Recover nudges provider id's refill rate back up after a success.
+func (s *RateLimiterSet) Recover(id string) {
+	s.bucketFor(id).Recover()
+}
+
+// Status returns provider id's current bucket fill for the admin API.
+func (s *RateLimiterSet) Status(id string) RateLimiterStatus {
+	return s.bucketFor(id).status()
+}
@@ -0,0 +1,83 @@
+package provider
+
+import "testing"
+
+func TestTokenBucketConsumeDrainsCapacity(t *testing.T) {
+	b := newTokenBucket(2)
+
+	if !b.HasCapacity() {
+		t.Fatal("expected a fresh bucket to have capacity")
+	}
+	b.Consume()
+	b.Consume()
+
+	if b.HasCapacity() {
+		t.Fatal("expected the bucket to be drained after consuming all tokens")
+	}
+}
+
+func TestTokenBucketDefaultsWhenRPSNotConfigured(t *testing.T) {
+	b := newTokenBucket(0)
+	if b.capacity != defaultBucketRPS {
+		t.Fatalf("expected default capacity %d, got %v", defaultBucketRPS, b.capacity)
+	}
+}
+
+func TestTokenBucketThrottleShrinksRefillRate(t *testing.T) {
+	b := newTokenBucket(10)
+
+	b.Throttle()
+	if b.refillPerSec != 5 {
+		t.Fatalf("expected refill rate to halve, got %v", b.refillPerSec)
+	}
+}
+
+func TestTokenBucketThrottleFloorsAtMinRefill(t *testing.T) {
+	b := newTokenBucket(1)
+
+	for i := 0; i < 10; i++ {
+		b.Throttle()
+	}
+	if b.refillPerSec != minRefillPerSec {
+		t.Fatalf("expected refill rate to floor at %v, got %v", minRefillPerSec, b.refillPerSec)
+	}
+}
+
+func TestTokenBucketRecoverCapsAtConfiguredRPS(t *testing.T) {
+	b := newTokenBucket(10)
+	b.Throttle()
+
+	for i := 0; i < 20; i++ {
+		b.Recover()
+	}
+	if b.refillPerSec != b.configuredRPS {
+		t.Fatalf("expected refill rate to recover back up to %v, got %v", b.configuredRPS, b.refillPerSec)
+	}
+}
+
+func TestRateLimiterSetBuildsBucketPerProvider(t *testing.T) {
+	set := NewRateLimiterSet([]*ProviderConfig{
+		{ID: "openai", RateLimitRPS: 5},
+		{ID: "anthropic", RateLimitRPS: 1},
+	})
+
+	set.Consume("openai")
+	status := set.Status("openai")
+	if status.Capacity != 5 {
+		t.Fatalf("expected openai's own configured capacity, got %v", status.Capacity)
+	}
+
+	set.Consume("anthropic")
+	other := set.Status("anthropic")
+	if other.Capacity != 1 {
+		t.Fatalf("expected anthropic's own configured capacity, got %v", other.Capacity)
+	}
+}
+
+func TestRateLimiterSetUnknownProviderGetsDefaultBucket(t *testing.T) {
+	set := NewRateLimiterSet(nil)
+
+	if !set.HasCapacity("never-configured") {
+		t.Fatal("expected an unconfigured provider to still get a usable default bucket")
+	}
+}
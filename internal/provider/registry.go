@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry holds the configured set of upstream providers, ordered by
+// priority (lower first) with weight used to break ties.
+type Registry struct {
+	mu        sync.RWMutex
+	providers []*ProviderConfig
+	byID      map[string]*ProviderConfig
+}
+
+// NewRegistry builds a Registry from the given provider configs, sorted by
+// priority ascending and then weight descending.
+func NewRegistry(configs []ProviderConfig) *Registry {
+	providers := make([]*ProviderConfig, len(configs))
+	byID := make(map[string]*ProviderConfig, len(configs))
+	for i := range configs {
+		p := configs[i]
+		providers[i] = &p
+		byID[p.ID] = providers[i]
+	}
+
+	sort.SliceStable(providers, func(i, j int) bool {
+		if providers[i].Priority != providers[j].Priority {
+			return providers[i].Priority < providers[j].Priority
+		}
+		return providers[i].Weight > providers[j].Weight
+	})
+
+	return &Registry{providers: providers, byID: byID}
+}
+
+// Ordered returns the configured providers in priority order.
+func (r *Registry) Ordered() []*ProviderConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*ProviderConfig, len(r.providers))
+	copy(out, r.providers)
+	return out
+}
+
+// Get looks up a provider by ID.
+func (r *Registry) Get(id string) (*ProviderConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.byID[id]
+	return p, ok
+}
+
+// Reload replaces the registry's providers, e.g. after a config file change.
+func (r *Registry) Reload(configs []ProviderConfig) {
+	next := NewRegistry(configs)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers = next.providers
+	r.byID = next.byID
+}
+
+// ErrUnknownProvider is returned when a PreferredProvider doesn't match any
+// configured provider ID.
+type ErrUnknownProvider struct {
+	ID string
+}
+
+func (e *ErrUnknownProvider) Error() string {
+	return fmt.Sprintf("unknown provider %q", e.ID)
+}
@@ -0,0 +1,49 @@
+package provider
+
+// Router decides which provider should serve a request, and which provider
+// to fall back to when a call fails.
+type Router struct {
+	registry *Registry
+	health   *HealthTracker
+	limiters *RateLimiterSet
+}
+
+// NewRouter builds a Router over the given registry, health tracker and
+// rate limiter set.
+func NewRouter(registry *Registry, health *HealthTracker, limiters *RateLimiterSet) *Router {
+	return &Router{registry: registry, health: health, limiters: limiters}
+}
+
+// Candidates returns the ordered list of providers to try for a request,
+// honoring preferredID when it names a known, healthy provider. If
+// preferredID is empty or unhealthy, providers are tried in priority order.
+// Providers whose health tracker marks them down, or whose rate-limit
+// bucket is already drained, are skipped before we ever attempt a call -
+// this lets the router route around throttling before the circuit breaker
+// would even trip.
+func (r *Router) Candidates(preferredID string) ([]*ProviderConfig, error) {
+	ordered := r.registry.Ordered()
+
+	if preferredID != "" {
+		preferred, ok := r.registry.Get(preferredID)
+		if !ok {
+			return nil, &ErrUnknownProvider{ID: preferredID}
+		}
+
+		rest := make([]*ProviderConfig, 0, len(ordered))
+		for _, p := range ordered {
+			if p.ID != preferredID {
+				rest = append(rest, p)
+			}
+		}
+		ordered = append([]*ProviderConfig{preferred}, rest...)
+	}
+
+	candidates := make([]*ProviderConfig, 0, len(ordered))
+	for _, p := range ordered {
+		if r.health.IsHealthy(p.ID) && r.limiters.HasCapacity(p.ID) {
+			candidates = append(candidates, p)
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/usage"
+)
+
+const (
+	maxStreamAttempts = 4
+	maxStreamDuration = 60 * time.Second
+)
+
+// bufferingWriter forwards every byte written to it straight through to the
+// client, while also reassembling the canonical OpenAI-style SSE chunks it
+// sees so the accumulated assistant text is available if the stream needs
+// to fail over mid-response.
+type bufferingWriter struct {
+	underlying io.Writer
+	pending    bytes.Buffer
+	content    bytes.Buffer
+}
+
+func (b *bufferingWriter) Write(p []byte) (int, error) {
+	n, err := b.underlying.Write(p)
+	if err != nil {
+		return n, err
+	}
+	b.pending.Write(p)
+	b.drainComplete()
+	return n, nil
+}
+
+func (b *bufferingWriter) drainComplete() {
+	for {
+		data := b.pending.Bytes()
+		idx := bytes.Index(data, []byte("\n\n"))
+		if idx < 0 {
+			break
+		}
+		b.consumeChunk(data[:idx])
+		b.pending.Next(idx + 2)
+	}
+}
+
+func (b *bufferingWriter) consumeChunk(chunk []byte) {
+	line := bytes.TrimPrefix(chunk, []byte("data: "))
+	if len(line) == 0 || bytes.Equal(line, []byte("[DONE]")) {
+		return
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(line, &parsed); err != nil {
+		return
+	}
+	for _, choice := range parsed.Choices {
+		b.content.WriteString(choice.Delta.Content)
+	}
+}
+
+// Buffered returns the assistant text reassembled from the chunks written
+// so far.
+func (b *bufferingWriter) Buffered() string {
+	return b.content.String()
+}
+
+// CallStream performs a streaming chat completion, failing over to the next
+// healthy provider if the stream breaks partway through. Already-sent
+// tokens stay on the client's connection; the next provider is asked to
+// continue from them rather than starting over, and a synthetic SSE comment
+// marks the switch so clients can observe it. [DONE] is only written once a
+// provider finishes cleanly. The returned Result's TokensUsed is an
+// estimate (via the usage package), since streamed replies never carry a
+// usage block.
+func (c *Client) CallStream(ctx context.Context, req models.Request, w io.Writer) (*Result, error) {
+	requestID, _ := ctx.Value("request_id").(string)
+
+	deadline := time.Now().Add(maxStreamDuration)
+	excluded := make(map[string]bool)
+	buf := &bufferingWriter{underlying: w}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if attempt > maxStreamAttempts {
+			return nil, fmt.Errorf("stream failover exhausted after %d attempts: %w", attempt-1, lastErr)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("stream failover exceeded time budget: %w", lastErr)
+		}
+
+		candidates, err := c.Router.Candidates(req.PreferredProvider)
+		if err != nil {
+			return nil, err
+		}
+		p := firstUnexcluded(candidates, excluded)
+		if p == nil {
+			return nil, fmt.Errorf("no healthy providers remaining: %w", lastErr)
+		}
+
+		if !c.Breaker.Allow(p.ID) {
+			excluded[p.ID] = true
+			continue
+		}
+
+		adapter, err := c.adapterFor(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt > 1 {
+			fmt.Fprintf(w, ": failover provider=%s attempt=%d\n\n", p.ID, attempt)
+			log.WithFields(log.Fields{
+				"request_id": requestID,
+				"provider":   p.ID,
+				"attempt":    attempt,
+				"event":      "stream_failover",
+			}).Warn("Failing over mid-stream")
+		}
+
+		streamReq := continuationRequest(req, buf.Buffered(), p.Adapter == "anthropic")
+		streamReq.Stream = true
+
+		httpReq, err := adapter.Translate(streamReq)
+		if err != nil {
+			return nil, fmt.Errorf("translate request for %s: %w", p.ID, err)
+		}
+		httpReq = httpReq.WithContext(ctx)
+		c.RateLimiter.Consume(p.ID)
+
+		resp, err := c.HTTPClient.Do(httpReq)
+		if err != nil {
+			c.Health.MarkFailure(p.ID, 0)
+			c.Breaker.RecordFailure(requestID, p.ID, FullJitterBackoff(backoffBase, backoffCap, attempt-1))
+			excluded[p.ID] = true
+			lastErr = fmt.Errorf("call provider %s: %w", p.ID, err)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			retryAfter, _ := ParseRetryAfter(resp.Header.Get("Retry-After"))
+			coolDown := backoffFor(resp.StatusCode, retryAfter, attempt-1)
+			c.Health.MarkFailure(p.ID, resp.StatusCode)
+			c.Breaker.RecordFailure(requestID, p.ID, coolDown)
+			if resp.StatusCode == http.StatusTooManyRequests {
+				c.RateLimiter.Throttle(p.ID)
+			}
+			excluded[p.ID] = true
+			lastErr = fmt.Errorf("provider %s returned status %d", p.ID, resp.StatusCode)
+			continue
+		}
+
+		err = adapter.ParseStream(resp.Body, buf)
+		resp.Body.Close()
+		if err != nil {
+			c.Health.MarkFailure(p.ID, 0)
+			c.Breaker.RecordFailure(requestID, p.ID, FullJitterBackoff(backoffBase, backoffCap, attempt-1))
+			excluded[p.ID] = true
+			lastErr = fmt.Errorf("stream from %s broke: %w", p.ID, err)
+			continue
+		}
+
+		c.Health.MarkSuccess(p.ID)
+		c.Breaker.RecordSuccess(p.ID)
+		c.RateLimiter.Recover(p.ID)
+		return &Result{
+			Provider:   p.ID,
+			Attempts:   attempt,
+			TokensUsed: usage.EstimateTokens(req.Model, buf.Buffered()),
+		}, nil
+	}
+}
+
+func firstUnexcluded(candidates []*ProviderConfig, excluded map[string]bool) *ProviderConfig {
+	for _, p := range candidates {
+		if !excluded[p.ID] {
+			return p
+		}
+	}
+	return nil
+}
+
+// continuationRequest rebuilds req so the next provider picks up where the
+// broken stream left off. Providers that support assistant-message prefill
+// (Anthropic) get the partial reply appended as a trailing assistant
+// message; others get a system nudge instead, since they reject a
+// conversation that ends on an assistant turn.
+func continuationRequest(req models.Request, buffered string, supportsPrefill bool) models.Request {
+	if buffered == "" {
+		return req
+	}
+
+	next := req
+	next.Messages = make([]models.Message, len(req.Messages))
+	copy(next.Messages, req.Messages)
+
+	if supportsPrefill {
+		next.Messages = append(next.Messages, models.Message{Role: "assistant", Content: buffered})
+		return next
+	}
+
+	next.Messages = append(next.Messages, models.Message{
+		Role:    "system",
+		Content: fmt.Sprintf("Continue your previous reply from exactly where it left off, with no repetition. Previously generated: %q", buffered),
+	})
+	return next
+}
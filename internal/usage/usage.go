@@ -0,0 +1,88 @@
+// Package usage parses token usage out of provider responses and estimates
+// it for streaming responses where no usage block is ever sent.
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// Usage is the normalized token accounting for a single provider call.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// FromOpenAIResponse parses the `usage` block out of a raw OpenAI-style chat
+// completion response body.
+func FromOpenAIResponse(body []byte) (Usage, error) {
+	var parsed struct {
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return Usage{}, fmt.Errorf("parse openai usage: %w", err)
+	}
+
+	return Usage{
+		PromptTokens:     parsed.Usage.PromptTokens,
+		CompletionTokens: parsed.Usage.CompletionTokens,
+		TotalTokens:      parsed.Usage.TotalTokens,
+	}, nil
+}
+
+// FromAnthropicResponse computes usage from Anthropic's
+// usage.input_tokens/output_tokens, which (unlike OpenAI) doesn't report a
+// combined total.
+func FromAnthropicResponse(body []byte) (Usage, error) {
+	var parsed struct {
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return Usage{}, fmt.Errorf("parse anthropic usage: %w", err)
+	}
+
+	return Usage{
+		PromptTokens:     parsed.Usage.InputTokens,
+		CompletionTokens: parsed.Usage.OutputTokens,
+		TotalTokens:      parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+	}, nil
+}
+
+// EstimateTokens estimates how many tokens text costs for model, for
+// streaming responses that never send a usage block. OpenAI models get an
+// exact count from tiktoken-go; everything else falls back to a
+// lightweight BPE approximation.
+func EstimateTokens(model, text string) int {
+	if text == "" {
+		return 0
+	}
+
+	if enc, err := tiktoken.EncodingForModel(model); err == nil {
+		return len(enc.Encode(text, nil, nil))
+	}
+
+	return approximateTokens(text)
+}
+
+// approximateTokens estimates a BPE-style token count without a real
+// vocabulary: roughly 4 characters per token, floored by a words-based
+// estimate so short, punctuation-heavy text isn't undercounted.
+func approximateTokens(text string) int {
+	byChars := len(text) / 4
+	byWords := int(float64(len(strings.Fields(text))) * 1.3)
+	if byWords > byChars {
+		return byWords
+	}
+	return byChars
+}
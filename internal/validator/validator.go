@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/AliZeynalov/LangDock-LLM-reliability/internal/models"
+)
+
+// ValidationErrors collects every validation failure found on a request so
+// the caller can report them all at once instead of failing fast.
+type ValidationErrors struct {
+	Errors []string
+}
+
+func (e *ValidationErrors) Error() string {
+	return fmt.Sprintf("validation failed: %v", e.Errors)
+}
+
+// ValidateRequest checks that an incoming chat completion request is
+// well-formed before it is handed to the provider client.
+func ValidateRequest(req *models.Request) error {
+	errs := &ValidationErrors{}
+
+	if req.Model == "" {
+		errs.Errors = append(errs.Errors, "model is required")
+	}
+
+	if len(req.Messages) == 0 {
+		errs.Errors = append(errs.Errors, "messages must not be empty")
+	}
+	for i, msg := range req.Messages {
+		switch msg.Role {
+		case "user", "assistant", "system":
+		default:
+			errs.Errors = append(errs.Errors, fmt.Sprintf("messages[%d]: unknown role %q", i, msg.Role))
+		}
+		if msg.Content == "" {
+			errs.Errors = append(errs.Errors, fmt.Sprintf("messages[%d]: content must not be empty", i))
+		}
+	}
+
+	if req.Temperature < 0 || req.Temperature > 2 {
+		errs.Errors = append(errs.Errors, "temperature must be between 0.0 and 2.0")
+	}
+
+	if req.MaxTokens < 0 {
+		errs.Errors = append(errs.Errors, "max_tokens must not be negative")
+	}
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}